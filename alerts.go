@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// WeatherAlert is a normalized hazard/warning covering some window of time,
+// regardless of which upstream service issued it.
+type WeatherAlert struct {
+	Event       string `json:"event"`
+	Severity    string `json:"severity"`
+	Start       string `json:"start"`
+	End         string `json:"end"`
+	Description string `json:"description"`
+}
+
+// alertSeverityRank orders severities from most to least urgent so alerts
+// can be sorted with the most dangerous hazard first. Severities not
+// present here (including an empty string) sort last - see severityRank,
+// which must be used instead of indexing this map directly since a missing
+// key's zero value would otherwise rank alongside "Extreme".
+var alertSeverityRank = map[string]int{
+	"Extreme":  0,
+	"Severe":   1,
+	"Moderate": 2,
+	"Minor":    3,
+}
+
+// severityRank looks up severity's rank, treating anything not present in
+// alertSeverityRank (including an empty string) as least urgent.
+func severityRank(severity string) int {
+	rank, ok := alertSeverityRank[severity]
+	if !ok {
+		return len(alertSeverityRank)
+	}
+	return rank
+}
+
+// AlertProvider fetches active weather alerts/hazards for a location. Not
+// every WeatherProvider implements this - callers should type-assert and
+// degrade gracefully when it isn't available.
+type AlertProvider interface {
+	FetchAlerts(ctx context.Context, lat, lon float64) ([]WeatherAlert, error)
+}
+
+// fetchAlerts looks up alerts from provider if it implements AlertProvider,
+// returning an empty slice (not an error) when the provider doesn't support
+// alerts at all.
+func fetchAlerts(ctx context.Context, provider WeatherProvider, lat, lon float64) ([]WeatherAlert, error) {
+	alertProvider, ok := provider.(AlertProvider)
+	if !ok {
+		return nil, nil
+	}
+
+	alerts, err := alertProvider.FetchAlerts(ctx, lat, lon)
+	if err != nil {
+		return nil, fmt.Errorf("fetching alerts: %w", err)
+	}
+
+	return dedupeAndSortAlerts(alerts), nil
+}
+
+// dedupeAndSortAlerts removes alerts that are identical in every field and
+// orders the remainder by severity, most urgent first.
+func dedupeAndSortAlerts(alerts []WeatherAlert) []WeatherAlert {
+	seen := make(map[WeatherAlert]bool, len(alerts))
+	deduped := make([]WeatherAlert, 0, len(alerts))
+	for _, alert := range alerts {
+		if seen[alert] {
+			continue
+		}
+		seen[alert] = true
+		deduped = append(deduped, alert)
+	}
+
+	sort.SliceStable(deduped, func(i, j int) bool {
+		return severityRank(deduped[i].Severity) < severityRank(deduped[j].Severity)
+	})
+
+	return deduped
+}
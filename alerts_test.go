@@ -0,0 +1,45 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDedupeAndSortAlerts(t *testing.T) {
+	minor := WeatherAlert{Event: "Wind", Severity: "Minor"}
+	severe := WeatherAlert{Event: "Flood", Severity: "Severe"}
+	extreme := WeatherAlert{Event: "Storm", Severity: "Extreme"}
+	unknown := WeatherAlert{Event: "Mystery", Severity: "Unspecified"}
+	empty := WeatherAlert{Event: "Blank", Severity: ""}
+
+	tests := []struct {
+		name  string
+		input []WeatherAlert
+		want  []WeatherAlert
+	}{
+		{
+			name:  "sorts most urgent first",
+			input: []WeatherAlert{minor, extreme, severe},
+			want:  []WeatherAlert{extreme, severe, minor},
+		},
+		{
+			name:  "unknown and empty severities sort last, not as Extreme",
+			input: []WeatherAlert{unknown, extreme, empty},
+			want:  []WeatherAlert{extreme, unknown, empty},
+		},
+		{
+			name:  "deduplicates identical alerts",
+			input: []WeatherAlert{severe, severe, minor},
+			want:  []WeatherAlert{severe, minor},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := dedupeAndSortAlerts(tt.input)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("dedupeAndSortAlerts(%v) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
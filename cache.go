@@ -0,0 +1,202 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+// ErrCacheMiss is returned by a Cache when it has no entry for a key.
+var ErrCacheMiss = errors.New("cache: no entry for key")
+
+// Cache persists the last successful payload fetched for a key, along with
+// the time it was fetched, so that a failed upstream call can fall back to
+// a recent-enough response instead of failing outright.
+type Cache interface {
+	Load(ctx context.Context, key string) (data []byte, fetchedAt time.Time, err error)
+	Store(ctx context.Context, key string, data []byte, fetchedAt time.Time) error
+}
+
+// cacheEnvelope is the on-disk/on-S3 representation of a cached entry.
+type cacheEnvelope struct {
+	FetchedAt time.Time       `json:"fetched_at"`
+	Body      json.RawMessage `json:"body"`
+}
+
+// NewCacheFromEnv builds the Cache configured by the CACHE_S3_BUCKET and
+// CACHE_DIR env vars, preferring S3 when a bucket is set and otherwise
+// falling back to disk (CACHE_DIR, defaulting to /tmp - the only writable
+// path on Lambda).
+func NewCacheFromEnv(ctx context.Context) (Cache, error) {
+	if bucket := os.Getenv("CACHE_S3_BUCKET"); bucket != "" {
+		cfg, err := awsconfig.LoadDefaultConfig(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("loading AWS config for S3 cache: %w", err)
+		}
+		return &S3Cache{Bucket: bucket, Client: s3.NewFromConfig(cfg)}, nil
+	}
+
+	dir := os.Getenv("CACHE_DIR")
+	if dir == "" {
+		dir = "/tmp"
+	}
+	return &DiskCache{Dir: dir}, nil
+}
+
+// cacheMaxAge reads CACHE_MAX_AGE_MINUTES, defaulting to 60 minutes.
+func cacheMaxAge() time.Duration {
+	minutes, err := strconv.Atoi(os.Getenv("CACHE_MAX_AGE_MINUTES"))
+	if err != nil || minutes <= 0 {
+		minutes = 60
+	}
+	return time.Duration(minutes) * time.Minute
+}
+
+// FetchWithCache calls fetch to populate data. If fetch succeeds, the
+// result is written through to cache under key. If fetch fails, the last
+// payload cached under key is used instead, provided it is no older than
+// maxAge - mirroring the disk-cache-with-staleness-limit pattern used by
+// similar polling Lambdas. It reports whether the cache was used and, if
+// so, the age of the served entry.
+func FetchWithCache[T any](ctx context.Context, cache Cache, key string, maxAge time.Duration, data *T, fetch func() error) (stale bool, age time.Duration, err error) {
+	fetchErr := fetch()
+	if fetchErr == nil {
+		if body, marshalErr := json.Marshal(data); marshalErr == nil {
+			if storeErr := cache.Store(ctx, key, body, time.Now().UTC()); storeErr != nil {
+				log.Println("cache: failed to store entry:", storeErr)
+			}
+		}
+		return false, 0, nil
+	}
+
+	cached, fetchedAt, loadErr := cache.Load(ctx, key)
+	if loadErr != nil {
+		return false, 0, fmt.Errorf("upstream fetch failed (%v) and no cache entry is available: %w", fetchErr, loadErr)
+	}
+
+	age = time.Since(fetchedAt)
+	if age > maxAge {
+		return false, 0, fmt.Errorf("upstream fetch failed (%v) and cached entry is %s old, older than the %s max age", fetchErr, age.Round(time.Minute), maxAge)
+	}
+
+	if err := json.Unmarshal(cached, data); err != nil {
+		return false, 0, fmt.Errorf("upstream fetch failed (%v) and cached entry is corrupt: %w", fetchErr, err)
+	}
+
+	return true, age, nil
+}
+
+// DiskCache persists cache entries as JSON files in Dir, one per key. It is
+// intended for Lambda's writable /tmp directory, which is preserved across
+// invocations on a warm container but not guaranteed to survive a cold
+// start.
+type DiskCache struct {
+	Dir string
+}
+
+func (c *DiskCache) pathFor(key string) string {
+	return filepath.Join(c.Dir, cacheFileName(key)+".json")
+}
+
+func (c *DiskCache) Load(ctx context.Context, key string) ([]byte, time.Time, error) {
+	f, err := os.Open(c.pathFor(key))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, time.Time{}, ErrCacheMiss
+		}
+		return nil, time.Time{}, err
+	}
+	defer f.Close()
+
+	raw, err := io.ReadAll(f)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	var envelope cacheEnvelope
+	if err := json.Unmarshal(raw, &envelope); err != nil {
+		return nil, time.Time{}, err
+	}
+	return envelope.Body, envelope.FetchedAt, nil
+}
+
+func (c *DiskCache) Store(ctx context.Context, key string, data []byte, fetchedAt time.Time) error {
+	if err := os.MkdirAll(c.Dir, 0o755); err != nil {
+		return err
+	}
+
+	raw, err := json.Marshal(cacheEnvelope{FetchedAt: fetchedAt, Body: data})
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(c.pathFor(key), raw, 0o644)
+}
+
+// S3Cache persists cache entries as objects in Bucket, one per key. Unlike
+// DiskCache it survives cold starts, at the cost of a network round trip.
+type S3Cache struct {
+	Bucket string
+	Client *s3.Client
+}
+
+func (c *S3Cache) Load(ctx context.Context, key string) ([]byte, time.Time, error) {
+	out, err := c.Client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(c.Bucket),
+		Key:    aws.String(cacheFileName(key) + ".json"),
+	})
+	if err != nil {
+		var noSuchKey *types.NoSuchKey
+		if errors.As(err, &noSuchKey) {
+			return nil, time.Time{}, ErrCacheMiss
+		}
+		return nil, time.Time{}, fmt.Errorf("getting %q from S3: %w", key, err)
+	}
+	defer out.Body.Close()
+
+	raw, err := io.ReadAll(out.Body)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	var envelope cacheEnvelope
+	if err := json.Unmarshal(raw, &envelope); err != nil {
+		return nil, time.Time{}, err
+	}
+	return envelope.Body, envelope.FetchedAt, nil
+}
+
+func (c *S3Cache) Store(ctx context.Context, key string, data []byte, fetchedAt time.Time) error {
+	raw, err := json.Marshal(cacheEnvelope{FetchedAt: fetchedAt, Body: data})
+	if err != nil {
+		return err
+	}
+
+	_, err = c.Client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket: aws.String(c.Bucket),
+		Key:    aws.String(cacheFileName(key) + ".json"),
+		Body:   bytes.NewReader(raw),
+	})
+	return err
+}
+
+// cacheFileName turns an arbitrary cache key (typically a URL) into
+// something safe to use as a file/object name.
+func cacheFileName(key string) string {
+	replacer := strings.NewReplacer("/", "_", ":", "_", "?", "_", "&", "_", "=", "_")
+	return replacer.Replace(key)
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+// fakeCache is an in-memory Cache for tests.
+type fakeCache struct {
+	entries map[string]cacheEnvelope
+}
+
+func newFakeCache() *fakeCache {
+	return &fakeCache{entries: make(map[string]cacheEnvelope)}
+}
+
+func (c *fakeCache) Load(ctx context.Context, key string) ([]byte, time.Time, error) {
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, time.Time{}, ErrCacheMiss
+	}
+	return entry.Body, entry.FetchedAt, nil
+}
+
+func (c *fakeCache) Store(ctx context.Context, key string, data []byte, fetchedAt time.Time) error {
+	c.entries[key] = cacheEnvelope{FetchedAt: fetchedAt, Body: data}
+	return nil
+}
+
+type fetchPayload struct {
+	Value string `json:"value"`
+}
+
+func TestFetchWithCache_SuccessStoresAndReturnsFresh(t *testing.T) {
+	cache := newFakeCache()
+	var data fetchPayload
+
+	stale, _, err := FetchWithCache(context.Background(), cache, "key", time.Hour, &data, func() error {
+		data = fetchPayload{Value: "fresh"}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stale {
+		t.Error("expected a successful fetch to not be reported stale")
+	}
+	if data.Value != "fresh" {
+		t.Errorf("data.Value = %q, want %q", data.Value, "fresh")
+	}
+	if _, ok := cache.entries["key"]; !ok {
+		t.Error("expected successful fetch to be written through to the cache")
+	}
+}
+
+func TestFetchWithCache_FallsBackToCacheOnFetchError(t *testing.T) {
+	cache := newFakeCache()
+	body, _ := json.Marshal(fetchPayload{Value: "cached"})
+	cache.entries["key"] = cacheEnvelope{FetchedAt: time.Now().UTC().Add(-10 * time.Minute), Body: body}
+
+	var data fetchPayload
+	stale, age, err := FetchWithCache(context.Background(), cache, "key", time.Hour, &data, func() error {
+		return errors.New("upstream down")
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !stale {
+		t.Error("expected cache fallback to be reported stale")
+	}
+	if age < 9*time.Minute || age > 11*time.Minute {
+		t.Errorf("age = %v, want ~10m", age)
+	}
+	if data.Value != "cached" {
+		t.Errorf("data.Value = %q, want %q", data.Value, "cached")
+	}
+}
+
+func TestFetchWithCache_ErrorsWhenCacheEntryOlderThanMaxAge(t *testing.T) {
+	cache := newFakeCache()
+	body, _ := json.Marshal(fetchPayload{Value: "cached"})
+	cache.entries["key"] = cacheEnvelope{FetchedAt: time.Now().UTC().Add(-2 * time.Hour), Body: body}
+
+	var data fetchPayload
+	_, _, err := FetchWithCache(context.Background(), cache, "key", time.Hour, &data, func() error {
+		return errors.New("upstream down")
+	})
+	if err == nil {
+		t.Fatal("expected an error when the cached entry exceeds max age")
+	}
+}
+
+func TestFetchWithCache_ErrorsWhenNoCacheEntryExists(t *testing.T) {
+	cache := newFakeCache()
+
+	var data fetchPayload
+	_, _, err := FetchWithCache(context.Background(), cache, "missing-key", time.Hour, &data, func() error {
+		return errors.New("upstream down")
+	})
+	if err == nil {
+		t.Fatal("expected an error when fetch fails and there is no cache entry")
+	}
+}
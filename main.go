@@ -1,15 +1,19 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
+	"flag"
 	"fmt"
 	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/tandemdude/weather_tide_monitor/wind"
 	"io"
 	"log"
 	"net/http"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -62,9 +66,21 @@ type TidesData struct {
 	Times     []string `json:"times"`
 }
 
+// BeaufortLabel is the force/name pair for a WindData reading, trimmed down
+// from wind.BeaufortScale so the JSON payload doesn't repeat the min/max
+// bounds for every response.
+type BeaufortLabel struct {
+	Force int    `json:"force"`
+	Name  string `json:"name"`
+}
+
 type WindData struct {
-	Direction string `json:"direction"`
-	Strength  string `json:"strength"`
+	Direction        string        `json:"direction"`
+	DirectionDegrees float64       `json:"direction_degrees"`
+	SpeedKmph        int16         `json:"speed_kmph"`
+	SpeedMph         float64       `json:"speed_mph"`
+	SpeedKnots       float64       `json:"speed_knots"`
+	Beaufort         BeaufortLabel `json:"beaufort"`
 }
 
 type LambdaResponse struct {
@@ -72,42 +88,42 @@ type LambdaResponse struct {
 	WeatherPeriods []WeatherPeriodData `json:"weather_periods"`
 	Tides          TidesData           `json:"tides"`
 	Wind           WindData            `json:"wind"`
+	Alerts         []WeatherAlert      `json:"alerts"`
 	Message        string              `json:"message"`
 }
 
 // --- LOGIC ---
-func WindSpeedText(speed int16) string {
-	if speed < 2 {
-		return "calm"
-	} else if speed < 6 {
-		return "light air"
-	} else if speed < 12 {
-		return "light breeze"
-	} else if speed < 20 {
-		return "gentle breeze"
-	} else if speed < 30 {
-		return "moderate breeze"
-	} else if speed < 40 {
-		return "fresh breeze"
-	} else if speed < 50 {
-		return "strong"
-	} else if speed < 62 {
-		return "near gale"
-	} else if speed < 75 {
-		return "gale"
-	} else if speed < 89 {
-		return "strong gale"
-	} else if speed < 103 {
-		return "storm"
-	} else if speed < 118 {
-		return "violent storm"
-	}
-	return "hurricane"
-}
-
-func GetData[T WeatherResponse | TideResponse](url string, data *T) error {
-	resp, err := http.Get(url)
-	if err != nil || resp.StatusCode > 299 {
+func GetData[T WeatherResponse | TideResponse | metNoResponse | openWeatherMapResponse](ctx context.Context, url string, data *T) error {
+	req, err := newGetRequest(ctx, url, "")
+	if err != nil {
+		return err
+	}
+	return doGetRequest(req, data)
+}
+
+// newGetRequest builds a GET request for url, attaching userAgent as the
+// User-Agent header when non-empty (some providers, e.g. MET Norway,
+// require one).
+func newGetRequest(ctx context.Context, url string, userAgent string) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("building request: %w", err)
+	}
+	if userAgent != "" {
+		req.Header.Set("User-Agent", userAgent)
+	}
+	return req, nil
+}
+
+// doGetRequest performs req and unmarshals the JSON response body into data.
+func doGetRequest[T any](req *http.Request, data *T) error {
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return errors.New("error making http request")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode > 299 {
 		return errors.New("error making http request")
 	}
 
@@ -122,49 +138,108 @@ func GetData[T WeatherResponse | TideResponse](url string, data *T) error {
 	return nil
 }
 
-func HandleLambdaEvent() LambdaResponse {
-	currentTime := time.Now().UTC()
-	weatherIndex := 0
+// ForecastParams overrides the defaults BuildForecast would otherwise pull
+// from the current time and environment. A nil field means "use the
+// default" (today/tomorrow's cutoff time, and the LATITUDE/LONGITUDE env
+// vars, respectively).
+type ForecastParams struct {
+	Date *time.Time
+	Lat  *float64
+	Lon  *float64
+}
 
-	if currentTime.Hour() >= 12 {
+// BuildForecast fetches tide and weather data for params and assembles the
+// LambdaResponse. It is the one place that shape is built, so both the
+// Lambda entrypoint and the HTTP server behave identically. A non-nil error
+// means the request could not be served at all; a populated Message on an
+// otherwise successful response indicates degraded (e.g. stale or
+// alert-less) data.
+func BuildForecast(ctx context.Context, params ForecastParams) (LambdaResponse, error) {
+	currentTime := time.Now().UTC()
+	if params.Date != nil {
+		currentTime = params.Date.UTC()
+	} else if currentTime.Hour() >= 12 {
 		currentTime = currentTime.AddDate(0, 0, 1)
 		currentTime = currentTime.Add(time.Hour * time.Duration(currentTime.Hour()) * -1)
-		weatherIndex = 1
 	}
 
 	lowTideOffset, err := strconv.Atoi(os.Getenv("LOW_TIDE_OFFSET"))
 	if err != nil {
-		log.Fatalln(err)
+		return LambdaResponse{}, fmt.Errorf("invalid or missing LOW_TIDE_OFFSET: %w", err)
 	}
 	highTideOffset, err := strconv.Atoi(os.Getenv("HIGH_TIDE_OFFSET"))
 	if err != nil {
-		log.Fatalln(err)
+		return LambdaResponse{}, fmt.Errorf("invalid or missing HIGH_TIDE_OFFSET: %w", err)
 	}
 	tideUrl := fmt.Sprintf(
 		"https://tidepredictions.pla.co.uk/gauge_data/0113/%s/%s/%s/0/1/",
 		currentTime.Format("2006"), currentTime.Format("01"), currentTime.Format("02"),
 	)
 
+	cache, err := NewCacheFromEnv(ctx)
+	if err != nil {
+		return LambdaResponse{}, fmt.Errorf("setting up cache: %w", err)
+	}
+	maxAge := cacheMaxAge()
+	var staleMessages []string
+
 	var tideData TideResponse
-	if err := GetData(tideUrl, &tideData); err != nil {
-		return LambdaResponse{
-			Message: "Could not fetch tide data",
-		}
+	tideStale, tideAge, err := FetchWithCache(ctx, cache, tideUrl, maxAge, &tideData, func() error {
+		return GetData(ctx, tideUrl, &tideData)
+	})
+	if err != nil {
+		return LambdaResponse{}, fmt.Errorf("fetching tide data: %w", err)
+	}
+	if tideStale {
+		staleMessages = append(staleMessages, fmt.Sprintf("tide data is %d minutes old", int(tideAge.Minutes())))
 	}
 	log.Println("Tide response fetched successfully")
-	var weatherData WeatherResponse
-	if err := GetData("https://wttr.in/Mortlake?format=j1", &weatherData); err != nil {
-		return LambdaResponse{
-			Message: "Could not fetch weather data",
+
+	provider, err := NewWeatherProvider()
+	if err != nil {
+		return LambdaResponse{}, fmt.Errorf("setting up weather provider: %w", err)
+	}
+	lat, lon := params.Lat, params.Lon
+	if lat == nil || lon == nil {
+		envLat, envLon, err := locationFromEnv()
+		if err != nil {
+			return LambdaResponse{}, fmt.Errorf("determining location: %w", err)
 		}
+		lat, lon = &envLat, &envLon
+	}
+	weatherCacheKey := fmt.Sprintf("weather:%T:%f:%f:%s", provider, *lat, *lon, currentTime.Format("2006-01-02"))
+	var forecast Forecast
+	weatherStale, weatherAge, err := FetchWithCache(ctx, cache, weatherCacheKey, maxAge, &forecast, func() error {
+		f, err := provider.Fetch(ctx, *lat, *lon, currentTime)
+		if err != nil {
+			return err
+		}
+		forecast = f
+		return nil
+	})
+	if err != nil {
+		return LambdaResponse{}, fmt.Errorf("fetching weather data: %w", err)
+	}
+	if weatherStale {
+		staleMessages = append(staleMessages, fmt.Sprintf("weather data is %d minutes old", int(weatherAge.Minutes())))
 	}
 	log.Println("Weather response fetched successfully")
 
+	alerts, err := fetchAlerts(ctx, provider, *lat, *lon)
+	if err != nil {
+		log.Println(err)
+		staleMessages = append(staleMessages, "could not fetch weather alerts")
+	}
+	alertMessage := ""
+	if len(staleMessages) > 0 {
+		alertMessage = "Stale data: " + strings.Join(staleMessages, "; ")
+	}
+
 	tideTimes := make([]string, 4)
 	for i, tide := range tideData.Table["0"].Rows[strconv.Itoa(currentTime.Day()-1)] {
 		parsed, err := time.Parse("2006-01-02 15:04", currentTime.Format("2006-01-02 ")+tide.Time[:2]+":"+tide.Time[2:])
 		if err != nil {
-			return LambdaResponse{Message: "Parser failure"}
+			return LambdaResponse{}, fmt.Errorf("parsing tide time: %w", err)
 		}
 
 		if tide.Type == 0 {
@@ -174,41 +249,31 @@ func HandleLambdaEvent() LambdaResponse {
 		}
 	}
 
-	var currentWeather HourlyWeather
-	dayWeather := make([]WeatherPeriodData, len(weatherData.Weather[weatherIndex].Hourly))
-	for i, weather := range weatherData.Weather[weatherIndex].Hourly {
-		hourTime, err := strconv.ParseInt(weather.Time, 10, 16)
+	var currentWeather ForecastPeriod
+	dayWeather := make([]WeatherPeriodData, len(forecast.Periods))
+	for i, period := range forecast.Periods {
+		hourTime, err := strconv.ParseInt(period.Time, 10, 16)
 		if err != nil {
-			return LambdaResponse{Message: "Parser failure"}
+			return LambdaResponse{}, fmt.Errorf("parsing weather period time: %w", err)
 		}
 		currTime, err := strconv.ParseInt(currentTime.Format("1504"), 10, 16)
 		if err != nil {
-			return LambdaResponse{Message: "Parser failure"}
+			return LambdaResponse{}, fmt.Errorf("parsing current time: %w", err)
 		}
 
 		if currTime >= hourTime {
-			currentWeather = weather
-		}
-
-		weatherType, err := strconv.ParseInt(weather.WeatherCode, 10, 16)
-		if err != nil {
-			return LambdaResponse{Message: "Parser failure"}
-		}
-		temperature, err := strconv.ParseInt(weather.TempC, 10, 16)
-		if err != nil {
-			return LambdaResponse{Message: "Parser failure"}
+			currentWeather = period
 		}
 
 		dayWeather[i] = WeatherPeriodData{
-			WeatherType: int16(weatherType),
-			Temperature: int16(temperature),
+			WeatherType: period.WeatherCode,
+			Temperature: period.TempC,
 		}
 	}
 
-	currWindSpeed, err := strconv.ParseInt(currentWeather.WindSpeedKmph, 10, 16)
-	if err != nil {
-		return LambdaResponse{Message: "Parser failure"}
-	}
+	speedKmph := float64(currentWeather.WindSpeedKmph)
+	beaufort := wind.Classify(speedKmph)
+	directionDegrees, _ := wind.DegreesFromCompass(currentWeather.WindDir16Point)
 
 	return LambdaResponse{
 		Date: currentTime.Format("2006-01-02"),
@@ -217,15 +282,46 @@ func HandleLambdaEvent() LambdaResponse {
 			Times:     tideTimes,
 		},
 		Wind: WindData{
-			Direction: currentWeather.WindDir16Point,
-			Strength:  WindSpeedText(int16(currWindSpeed)),
+			Direction:        currentWeather.WindDir16Point,
+			DirectionDegrees: directionDegrees,
+			SpeedKmph:        currentWeather.WindSpeedKmph,
+			SpeedMph:         wind.ToMph(speedKmph),
+			SpeedKnots:       wind.ToKnots(speedKmph),
+			Beaufort:         BeaufortLabel{Force: beaufort.Force, Name: beaufort.Name},
 		},
+		Alerts:         alerts,
+		Message:        alertMessage,
 		WeatherPeriods: dayWeather,
+	}, nil
+}
+
+// HandleLambdaEvent is the AWS Lambda entrypoint. It preserves the
+// pre-existing contract of reporting failures via LambdaResponse.Message
+// rather than a Go error, since lambda.Start always serializes whatever is
+// returned here as the response payload.
+func HandleLambdaEvent() LambdaResponse {
+	resp, err := BuildForecast(context.Background(), ForecastParams{})
+	if err != nil {
+		return LambdaResponse{Message: err.Error()}
 	}
+	return resp
 }
 
 func main() {
-	lambda.Start(HandleLambdaEvent)
+	mode := flag.String("mode", "lambda", "how to run the monitor: \"lambda\" (default) or \"http\"")
+	addr := flag.String("addr", ":8080", "address to listen on when -mode=http")
+	flag.Parse()
+
+	switch *mode {
+	case "http":
+		if err := ServeHTTP(*addr); err != nil {
+			log.Fatalln(err)
+		}
+	case "lambda":
+		lambda.Start(HandleLambdaEvent)
+	default:
+		log.Fatalf("unknown -mode %q", *mode)
+	}
 }
 
 // Tides for london bridge -> chiswick bridge - HW = +46m, LW = +144m
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ForecastPeriod is a single normalized weather reading for one point in
+// time, independent of whichever upstream provider produced it.
+type ForecastPeriod struct {
+	Time           string
+	TempC          int16
+	WeatherCode    int16
+	WindSpeedKmph  int16
+	WindDir16Point string
+}
+
+// Forecast is the provider-agnostic result of fetching weather data for a
+// given day. Periods are ordered chronologically.
+type Forecast struct {
+	Periods []ForecastPeriod
+}
+
+// WeatherProvider fetches a day's forecast for a given location from some
+// upstream weather service.
+type WeatherProvider interface {
+	Fetch(ctx context.Context, lat, lon float64, date time.Time) (Forecast, error)
+}
+
+// NewWeatherProvider builds the WeatherProvider selected by the
+// WEATHER_PROVIDER env var ("wttrin", "metno" or "openweathermap"),
+// defaulting to wttrin for backwards compatibility.
+func NewWeatherProvider() (WeatherProvider, error) {
+	switch strings.ToLower(os.Getenv("WEATHER_PROVIDER")) {
+	case "", "wttrin":
+		return &WttrInProvider{}, nil
+	case "metno":
+		userAgent := os.Getenv("METNO_USER_AGENT")
+		if userAgent == "" {
+			return nil, fmt.Errorf("METNO_USER_AGENT must be set when WEATHER_PROVIDER=metno")
+		}
+		return &MetNoProvider{UserAgent: userAgent}, nil
+	case "openweathermap", "owm":
+		apiKey := os.Getenv("OPENWEATHERMAP_API_KEY")
+		if apiKey == "" {
+			return nil, fmt.Errorf("OPENWEATHERMAP_API_KEY must be set when WEATHER_PROVIDER=openweathermap")
+		}
+		return &OpenWeatherMapProvider{APIKey: apiKey}, nil
+	default:
+		return nil, fmt.Errorf("unknown WEATHER_PROVIDER %q", os.Getenv("WEATHER_PROVIDER"))
+	}
+}
+
+// locationFromEnv reads the LATITUDE/LONGITUDE env vars used by providers
+// that require coordinates rather than a place name.
+func locationFromEnv() (lat, lon float64, err error) {
+	lat, err = strconv.ParseFloat(os.Getenv("LATITUDE"), 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid or missing LATITUDE: %w", err)
+	}
+	lon, err = strconv.ParseFloat(os.Getenv("LONGITUDE"), 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid or missing LONGITUDE: %w", err)
+	}
+	return lat, lon, nil
+}
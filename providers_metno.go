@@ -0,0 +1,126 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"github.com/tandemdude/weather_tide_monitor/wind"
+	"strings"
+	"time"
+)
+
+// metNoResponse is the subset of MET Norway's locationforecast/2.0/compact
+// response that we care about.
+type metNoResponse struct {
+	Properties struct {
+		Timeseries []struct {
+			Time string `json:"time"`
+			Data struct {
+				Instant struct {
+					Details struct {
+						AirTemperature    float64 `json:"air_temperature"`
+						WindSpeed         float64 `json:"wind_speed"`
+						WindFromDirection float64 `json:"wind_from_direction"`
+					} `json:"details"`
+				} `json:"instant"`
+				Next1Hours struct {
+					Summary struct {
+						SymbolCode string `json:"symbol_code"`
+					} `json:"summary"`
+				} `json:"next_1_hours"`
+			} `json:"data"`
+		} `json:"timeseries"`
+	} `json:"properties"`
+}
+
+// metNoSymbolToWeatherCode maps MET Norway's symbol_code identifiers to the
+// worldweatheronline-style numeric codes used elsewhere in this package, so
+// that LambdaResponse.WeatherPeriods stays provider-agnostic. Many
+// symbol_codes carry a "_day", "_night" or "_polartwilight" suffix (e.g.
+// "clearsky_day"), so any such suffix is stripped before this lookup - keys
+// here are the bare condition names. Unmapped symbols fall back to a generic
+// "cloudy" code.
+var metNoSymbolToWeatherCode = map[string]int16{
+	"clearsky":              113,
+	"fair":                  116,
+	"partlycloudy":          116,
+	"cloudy":                119,
+	"rainshowers":           176,
+	"rainshowersandthunder": 389,
+	"rain":                  302,
+	"lightrain":             266,
+	"heavyrain":             308,
+	"snow":                  332,
+	"lightsnow":             320,
+	"heavysnow":             338,
+	"sleet":                 317,
+	"fog":                   248,
+	"thunder":               389,
+}
+
+// metNoSymbolSuffixes are the time-of-day qualifiers MET Norway appends to
+// every symbol_code.
+var metNoSymbolSuffixes = [...]string{"_day", "_night", "_polartwilight"}
+
+// stripMetNoSymbolSuffix removes a trailing "_day"/"_night"/"_polartwilight"
+// qualifier from a MET Norway symbol_code, leaving the bare condition name
+// used as a metNoSymbolToWeatherCode key.
+func stripMetNoSymbolSuffix(symbolCode string) string {
+	for _, suffix := range metNoSymbolSuffixes {
+		if strings.HasSuffix(symbolCode, suffix) {
+			return strings.TrimSuffix(symbolCode, suffix)
+		}
+	}
+	return symbolCode
+}
+
+// MetNoProvider fetches forecasts from MET Norway's locationforecast API.
+// UserAgent is required by MET Norway's terms of service and is sent as the
+// User-Agent header on every request.
+type MetNoProvider struct {
+	UserAgent string
+}
+
+func (p *MetNoProvider) Fetch(ctx context.Context, lat, lon float64, date time.Time) (Forecast, error) {
+	url := fmt.Sprintf("https://api.met.no/weatherapi/locationforecast/2.0/compact?lat=%f&lon=%f", lat, lon)
+
+	req, err := newGetRequest(ctx, url, p.UserAgent)
+	if err != nil {
+		return Forecast{}, err
+	}
+
+	var data metNoResponse
+	if err := doGetRequest(req, &data); err != nil {
+		return Forecast{}, fmt.Errorf("fetching MET Norway data: %w", err)
+	}
+
+	dateStr := date.Format("2006-01-02")
+	var periods []ForecastPeriod
+	for _, entry := range data.Properties.Timeseries {
+		t, err := time.Parse(time.RFC3339, entry.Time)
+		if err != nil {
+			continue
+		}
+		if t.Format("2006-01-02") != dateStr {
+			continue
+		}
+
+		condition := stripMetNoSymbolSuffix(entry.Data.Next1Hours.Summary.SymbolCode)
+		code, ok := metNoSymbolToWeatherCode[condition]
+		if !ok {
+			code = metNoSymbolToWeatherCode["cloudy"]
+		}
+
+		periods = append(periods, ForecastPeriod{
+			Time:           t.Format("1504"),
+			TempC:          int16(entry.Data.Instant.Details.AirTemperature),
+			WeatherCode:    code,
+			WindSpeedKmph:  int16(entry.Data.Instant.Details.WindSpeed * 3.6),
+			WindDir16Point: wind.CompassFromDegrees(entry.Data.Instant.Details.WindFromDirection),
+		})
+	}
+	if len(periods) == 0 {
+		return Forecast{}, fmt.Errorf("MET Norway response did not contain any entries for %s", dateStr)
+	}
+
+	return Forecast{Periods: periods}, nil
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"github.com/tandemdude/weather_tide_monitor/wind"
+	"time"
+)
+
+// openWeatherMapResponse is the subset of the One Call 3.0 response that we
+// care about.
+type openWeatherMapResponse struct {
+	Hourly []struct {
+		Dt        int64   `json:"dt"`
+		Temp      float64 `json:"temp"`
+		WindSpeed float64 `json:"wind_speed"`
+		WindDeg   float64 `json:"wind_deg"`
+		Weather   []struct {
+			ID int16 `json:"id"`
+		} `json:"weather"`
+	} `json:"hourly"`
+	Alerts []struct {
+		Event       string `json:"event"`
+		Start       int64  `json:"start"`
+		End         int64  `json:"end"`
+		Description string `json:"description"`
+	} `json:"alerts"`
+}
+
+// OpenWeatherMapProvider fetches forecasts from OpenWeatherMap's One Call
+// API.
+type OpenWeatherMapProvider struct {
+	APIKey string
+}
+
+func (p *OpenWeatherMapProvider) Fetch(ctx context.Context, lat, lon float64, date time.Time) (Forecast, error) {
+	url := fmt.Sprintf(
+		"https://api.openweathermap.org/data/3.0/onecall?lat=%f&lon=%f&units=metric&exclude=minutely,daily,current,alerts&appid=%s",
+		lat, lon, p.APIKey,
+	)
+
+	var data openWeatherMapResponse
+	if err := GetData(ctx, url, &data); err != nil {
+		return Forecast{}, fmt.Errorf("fetching OpenWeatherMap data: %w", err)
+	}
+
+	dateStr := date.Format("2006-01-02")
+	var periods []ForecastPeriod
+	for _, hour := range data.Hourly {
+		t := time.Unix(hour.Dt, 0).UTC()
+		if t.Format("2006-01-02") != dateStr {
+			continue
+		}
+
+		var weatherCode int16
+		if len(hour.Weather) > 0 {
+			weatherCode = owmConditionToWeatherCode(hour.Weather[0].ID)
+		}
+
+		periods = append(periods, ForecastPeriod{
+			Time:           t.Format("1504"),
+			TempC:          int16(hour.Temp),
+			WeatherCode:    weatherCode,
+			WindSpeedKmph:  int16(hour.WindSpeed * 3.6),
+			WindDir16Point: wind.CompassFromDegrees(hour.WindDeg),
+		})
+	}
+	if len(periods) == 0 {
+		return Forecast{}, fmt.Errorf("OpenWeatherMap response did not contain any entries for %s", dateStr)
+	}
+
+	return Forecast{Periods: periods}, nil
+}
+
+// FetchAlerts implements AlertProvider using the One Call "alerts" field.
+// OpenWeatherMap does not expose a severity, so every alert is reported as
+// "Severe" - downstream de-duplication and sorting still apply.
+func (p *OpenWeatherMapProvider) FetchAlerts(ctx context.Context, lat, lon float64) ([]WeatherAlert, error) {
+	url := fmt.Sprintf(
+		"https://api.openweathermap.org/data/3.0/onecall?lat=%f&lon=%f&units=metric&exclude=minutely,daily,current,hourly&appid=%s",
+		lat, lon, p.APIKey,
+	)
+
+	var data openWeatherMapResponse
+	if err := GetData(ctx, url, &data); err != nil {
+		return nil, fmt.Errorf("fetching OpenWeatherMap alerts: %w", err)
+	}
+
+	alerts := make([]WeatherAlert, len(data.Alerts))
+	for i, a := range data.Alerts {
+		alerts[i] = WeatherAlert{
+			Event:       a.Event,
+			Severity:    "Severe",
+			Start:       time.Unix(a.Start, 0).UTC().Format(time.RFC3339),
+			End:         time.Unix(a.End, 0).UTC().Format(time.RFC3339),
+			Description: a.Description,
+		}
+	}
+
+	return alerts, nil
+}
+
+// owmConditionToWeatherCode maps OpenWeatherMap's condition IDs
+// (https://openweathermap.org/weather-conditions) to the
+// worldweatheronline-style numeric codes used elsewhere in this package.
+func owmConditionToWeatherCode(id int16) int16 {
+	switch {
+	case id >= 200 && id < 300:
+		return 389 // thunderstorm
+	case id >= 300 && id < 400:
+		return 266 // drizzle
+	case id >= 500 && id < 600:
+		return 302 // rain
+	case id >= 600 && id < 700:
+		return 332 // snow
+	case id >= 700 && id < 800:
+		return 248 // atmosphere (fog, mist, haze, ...)
+	case id == 800:
+		return 113 // clear sky
+	case id > 800:
+		return 116 // clouds
+	default:
+		return 119 // cloudy fallback
+	}
+}
@@ -0,0 +1,72 @@
+package main
+
+import "testing"
+
+func TestStripMetNoSymbolSuffix(t *testing.T) {
+	tests := []struct {
+		symbolCode string
+		want       string
+	}{
+		{"clearsky_day", "clearsky"},
+		{"fair_night", "fair"},
+		{"partlycloudy_polartwilight", "partlycloudy"},
+		{"rainshowersandthunder_day", "rainshowersandthunder"},
+		{"cloudy", "cloudy"}, // no suffix to strip
+	}
+
+	for _, tt := range tests {
+		if got := stripMetNoSymbolSuffix(tt.symbolCode); got != tt.want {
+			t.Errorf("stripMetNoSymbolSuffix(%q) = %q, want %q", tt.symbolCode, got, tt.want)
+		}
+	}
+}
+
+func TestMetNoSymbolToWeatherCode(t *testing.T) {
+	// Suffixed symbol_code values in the form MET Norway returns them; the
+	// last is not a known condition and exercises the fallback.
+	tests := []struct {
+		symbolCode string
+		want       int16
+	}{
+		{"clearsky_day", 113},
+		{"clearsky_night", 113},
+		{"fair_night", 116},
+		{"partlycloudy_polartwilight", 116},
+		{"rainshowers_day", 176},
+		{"rainshowersandthunder_day", 389},
+		{"unknown_condition_day", 119}, // falls back to cloudy
+	}
+
+	for _, tt := range tests {
+		condition := stripMetNoSymbolSuffix(tt.symbolCode)
+		code, ok := metNoSymbolToWeatherCode[condition]
+		if !ok {
+			code = metNoSymbolToWeatherCode["cloudy"]
+		}
+		if code != tt.want {
+			t.Errorf("weather code for %q = %d, want %d", tt.symbolCode, code, tt.want)
+		}
+	}
+}
+
+func TestOwmConditionToWeatherCode(t *testing.T) {
+	tests := []struct {
+		id   int16
+		want int16
+	}{
+		{200, 389}, // thunderstorm
+		{300, 266}, // drizzle
+		{500, 302}, // rain
+		{600, 332}, // snow
+		{741, 248}, // fog
+		{800, 113}, // clear sky
+		{801, 116}, // few clouds
+		{0, 119},   // unrecognized, falls back to cloudy
+	}
+
+	for _, tt := range tests {
+		if got := owmConditionToWeatherCode(tt.id); got != tt.want {
+			t.Errorf("owmConditionToWeatherCode(%d) = %d, want %d", tt.id, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// WttrInProvider fetches forecasts from wttr.in, keyed by coordinates
+// rather than a hard-coded place name.
+type WttrInProvider struct{}
+
+func (p *WttrInProvider) Fetch(ctx context.Context, lat, lon float64, date time.Time) (Forecast, error) {
+	url := fmt.Sprintf("https://wttr.in/%s,%s?format=j1", strconv.FormatFloat(lat, 'f', 4, 64), strconv.FormatFloat(lon, 'f', 4, 64))
+
+	var weatherData WeatherResponse
+	if err := GetData(ctx, url, &weatherData); err != nil {
+		return Forecast{}, fmt.Errorf("fetching wttr.in data: %w", err)
+	}
+
+	dateStr := date.Format("2006-01-02")
+	var day *WeatherDay
+	for i, d := range weatherData.Weather {
+		if d.Date == dateStr {
+			day = &weatherData.Weather[i]
+			break
+		}
+	}
+	if day == nil {
+		return Forecast{}, fmt.Errorf("wttr.in response did not contain a day for %s", dateStr)
+	}
+
+	periods := make([]ForecastPeriod, len(day.Hourly))
+	for i, hour := range day.Hourly {
+		weatherCode, err := strconv.ParseInt(hour.WeatherCode, 10, 16)
+		if err != nil {
+			return Forecast{}, fmt.Errorf("parsing weather code: %w", err)
+		}
+		tempC, err := strconv.ParseInt(hour.TempC, 10, 16)
+		if err != nil {
+			return Forecast{}, fmt.Errorf("parsing temperature: %w", err)
+		}
+		windSpeed, err := strconv.ParseInt(hour.WindSpeedKmph, 10, 16)
+		if err != nil {
+			return Forecast{}, fmt.Errorf("parsing wind speed: %w", err)
+		}
+
+		periods[i] = ForecastPeriod{
+			Time:           hour.Time,
+			TempC:          int16(tempC),
+			WeatherCode:    int16(weatherCode),
+			WindSpeedKmph:  int16(windSpeed),
+			WindDir16Point: hour.WindDir16Point,
+		}
+	}
+
+	return Forecast{Periods: periods}, nil
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ServeHTTP starts an HTTP server on addr exposing GET /forecast, blocking
+// until the server exits.
+func ServeHTTP(addr string) error {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/forecast", forecastHandler)
+
+	log.Printf("listening on %s", addr)
+	return http.ListenAndServe(addr, mux)
+}
+
+// forecastHandler serves GET /forecast?date=YYYY-MM-DD&location=lat,lon,
+// both query params optional, falling back to BuildForecast's own defaults.
+func forecastHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	params, err := parseForecastParams(r)
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	resp, err := BuildForecast(r.Context(), params)
+	if err != nil {
+		writeJSONError(w, http.StatusBadGateway, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Println("writing forecast response:", err)
+	}
+}
+
+func parseForecastParams(r *http.Request) (ForecastParams, error) {
+	var params ForecastParams
+
+	if date := r.URL.Query().Get("date"); date != "" {
+		parsed, err := time.Parse("2006-01-02", date)
+		if err != nil {
+			return ForecastParams{}, fmt.Errorf("invalid date %q, expected YYYY-MM-DD: %w", date, err)
+		}
+		params.Date = &parsed
+	}
+
+	if location := r.URL.Query().Get("location"); location != "" {
+		parts := strings.SplitN(location, ",", 2)
+		if len(parts) != 2 {
+			return ForecastParams{}, fmt.Errorf("invalid location %q, expected \"lat,lon\"", location)
+		}
+		lat, err := strconv.ParseFloat(strings.TrimSpace(parts[0]), 64)
+		if err != nil {
+			return ForecastParams{}, fmt.Errorf("invalid latitude in location %q: %w", location, err)
+		}
+		lon, err := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+		if err != nil {
+			return ForecastParams{}, fmt.Errorf("invalid longitude in location %q: %w", location, err)
+		}
+		params.Lat, params.Lon = &lat, &lon
+	}
+
+	return params, nil
+}
+
+func writeJSONError(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+}
@@ -0,0 +1,85 @@
+// Package wind provides structured wind speed classification and unit
+// conversion, so callers don't have to re-parse a human-readable string to
+// get at the underlying speed or direction.
+package wind
+
+// BeaufortScale describes one step of the Beaufort wind force scale.
+type BeaufortScale struct {
+	Force            int
+	Name             string
+	MinKmph          float64
+	MaxKmph          float64
+	DescriptionShort string
+}
+
+// scale is ordered by increasing force; the final entry's MaxKmph is
+// unbounded.
+var scale = []BeaufortScale{
+	{0, "Calm", 0, 2, "calm"},
+	{1, "Light air", 2, 6, "light air"},
+	{2, "Light breeze", 6, 12, "light breeze"},
+	{3, "Gentle breeze", 12, 20, "gentle breeze"},
+	{4, "Moderate breeze", 20, 30, "moderate breeze"},
+	{5, "Fresh breeze", 30, 40, "fresh breeze"},
+	{6, "Strong breeze", 40, 50, "strong"},
+	{7, "Near gale", 50, 62, "near gale"},
+	{8, "Gale", 62, 75, "gale"},
+	{9, "Strong gale", 75, 89, "strong gale"},
+	{10, "Storm", 89, 103, "storm"},
+	{11, "Violent storm", 103, 118, "violent storm"},
+	{12, "Hurricane", 118, -1, "hurricane"},
+}
+
+// Classify returns the Beaufort scale entry covering speedKmph.
+func Classify(speedKmph float64) BeaufortScale {
+	for _, s := range scale {
+		if s.MaxKmph < 0 || speedKmph < s.MaxKmph {
+			return s
+		}
+	}
+	return scale[len(scale)-1]
+}
+
+// ToMph converts a speed in km/h to mph.
+func ToMph(speedKmph float64) float64 {
+	return speedKmph / 1.609344
+}
+
+// ToKnots converts a speed in km/h to knots.
+func ToKnots(speedKmph float64) float64 {
+	return speedKmph / 1.852
+}
+
+// ToMs converts a speed in km/h to m/s.
+func ToMs(speedKmph float64) float64 {
+	return speedKmph / 3.6
+}
+
+// compassPoints16 are the 16-point compass directions in clockwise order,
+// starting from north.
+var compassPoints16 = [...]string{
+	"N", "NNE", "NE", "ENE", "E", "ESE", "SE", "SSE",
+	"S", "SSW", "SW", "WSW", "W", "WNW", "NW", "NNW",
+}
+
+// DegreesFromCompass converts a 16-point compass direction (e.g. "NNE") to
+// its bearing in degrees. It reports false if point is not one of the 16
+// recognized directions.
+func DegreesFromCompass(point string) (degrees float64, ok bool) {
+	for i, p := range compassPoints16 {
+		if p == point {
+			return float64(i) * 22.5, true
+		}
+	}
+	return 0, false
+}
+
+// CompassFromDegrees converts a wind bearing in degrees to its nearest
+// 16-point compass direction.
+func CompassFromDegrees(degrees float64) string {
+	idx := int(degrees/22.5+0.5) % 16
+	if idx < 0 {
+		idx += 16
+	}
+	return compassPoints16[idx]
+}
@@ -0,0 +1,78 @@
+package wind
+
+import "testing"
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		speedKmph float64
+		wantForce int
+	}{
+		{0, 0},
+		{1.9, 0},
+		{2, 1}, // lower bound of a band is exclusive of the band below
+		{5.9, 1},
+		{6, 2},
+		{117.9, 11},
+		{118, 12},
+		{500, 12}, // unbounded top band
+	}
+
+	for _, tt := range tests {
+		got := Classify(tt.speedKmph)
+		if got.Force != tt.wantForce {
+			t.Errorf("Classify(%v).Force = %d, want %d", tt.speedKmph, got.Force, tt.wantForce)
+		}
+	}
+}
+
+func TestUnitConversions(t *testing.T) {
+	const speedKmph = 36.0 // a round number in km/h
+
+	if got, want := ToMph(speedKmph), 22.369; abs(got-want) > 0.01 {
+		t.Errorf("ToMph(%v) = %v, want ~%v", speedKmph, got, want)
+	}
+	if got, want := ToKnots(speedKmph), 19.438; abs(got-want) > 0.01 {
+		t.Errorf("ToKnots(%v) = %v, want ~%v", speedKmph, got, want)
+	}
+	if got, want := ToMs(speedKmph), 10.0; abs(got-want) > 0.01 {
+		t.Errorf("ToMs(%v) = %v, want ~%v", speedKmph, got, want)
+	}
+}
+
+func TestCompassDegreesRoundTrip(t *testing.T) {
+	tests := []struct {
+		point   string
+		degrees float64
+	}{
+		{"N", 0},
+		{"NNE", 22.5},
+		{"E", 90},
+		{"S", 180},
+		{"NNW", 337.5},
+	}
+
+	for _, tt := range tests {
+		gotDegrees, ok := DegreesFromCompass(tt.point)
+		if !ok {
+			t.Errorf("DegreesFromCompass(%q) reported not ok", tt.point)
+		}
+		if gotDegrees != tt.degrees {
+			t.Errorf("DegreesFromCompass(%q) = %v, want %v", tt.point, gotDegrees, tt.degrees)
+		}
+
+		if gotPoint := CompassFromDegrees(tt.degrees); gotPoint != tt.point {
+			t.Errorf("CompassFromDegrees(%v) = %q, want %q", tt.degrees, gotPoint, tt.point)
+		}
+	}
+
+	if _, ok := DegreesFromCompass("not-a-direction"); ok {
+		t.Error("DegreesFromCompass(\"not-a-direction\") reported ok, want false")
+	}
+}
+
+func abs(f float64) float64 {
+	if f < 0 {
+		return -f
+	}
+	return f
+}